@@ -0,0 +1,160 @@
+package main
+
+import (
+	"os"
+	"path/filepath"
+	"sort"
+	"strings"
+	"sync"
+
+	"github.com/charmbracelet/log"
+	"github.com/fsnotify/fsnotify"
+)
+
+// Project is a single content/projects/*.md entry.
+type Project struct {
+	Title string
+	Body  string
+	Order int
+}
+
+// siteContent holds everything loaded from content/, guarded by mu since the
+// fsnotify watcher reloads it from a separate goroutine while sessions read
+// it concurrently.
+type siteContent struct {
+	mu sync.RWMutex
+
+	layout      string
+	homeBody    string
+	contactBody string
+	projects    []Project
+}
+
+var content = &siteContent{}
+
+// load reads content/home.md, content/contact.md, and content/projects/*.md
+// from dir and atomically swaps them into the receiver.
+func (c *siteContent) load(dir string) error {
+	homeRaw, err := os.ReadFile(filepath.Join(dir, "home.md"))
+	if err != nil {
+		return err
+	}
+	homeBody, homeFm := SplitFrontmatterMarkdown(string(homeRaw))
+
+	layout := "two-column"
+	if l, ok := homeFm["layout"].(string); ok && l != "" {
+		layout = l
+	}
+
+	contactRaw, err := os.ReadFile(filepath.Join(dir, "contact.md"))
+	if err != nil {
+		return err
+	}
+	contactBody, _ := SplitFrontmatterMarkdown(string(contactRaw))
+
+	projects, err := loadProjects(filepath.Join(dir, "projects"))
+	if err != nil {
+		return err
+	}
+
+	c.mu.Lock()
+	c.layout = layout
+	c.homeBody = strings.TrimSuffix(homeBody, "\n")
+	c.contactBody = strings.TrimSuffix(contactBody, "\n")
+	c.projects = projects
+	c.mu.Unlock()
+
+	return nil
+}
+
+// snapshot returns a consistent, read-only copy of the current content.
+func (c *siteContent) snapshot() (layout, homeBody, contactBody string, projects []Project) {
+	c.mu.RLock()
+	defer c.mu.RUnlock()
+	return c.layout, c.homeBody, c.contactBody, append([]Project(nil), c.projects...)
+}
+
+// loadProjects reads every *.md file in dir and returns the resulting
+// projects sorted by their `order:` frontmatter key ascending.
+func loadProjects(dir string) ([]Project, error) {
+	entries, err := os.ReadDir(dir)
+	if err != nil {
+		return nil, err
+	}
+
+	var projects []Project
+
+	for _, entry := range entries {
+		if entry.IsDir() || filepath.Ext(entry.Name()) != ".md" {
+			continue
+		}
+
+		raw, err := os.ReadFile(filepath.Join(dir, entry.Name()))
+		if err != nil {
+			return nil, err
+		}
+
+		body, fm := SplitFrontmatterMarkdown(string(raw))
+
+		proj := Project{Body: strings.TrimSuffix(body, "\n")}
+
+		if title, ok := fm["title"].(string); ok {
+			proj.Title = title
+		}
+		if order, ok := fm["order"].(int); ok {
+			proj.Order = order
+		}
+
+		projects = append(projects, proj)
+	}
+
+	sort.Slice(projects, func(i, j int) bool {
+		return projects[i].Order < projects[j].Order
+	})
+
+	return projects, nil
+}
+
+// watchContent watches dir for changes and reloads it into c whenever a file
+// is created, written, or removed, invoking onReload afterwards so active
+// sessions can re-render with the new content.
+func watchContent(dir string, c *siteContent, onReload func()) error {
+	watcher, err := fsnotify.NewWatcher()
+	if err != nil {
+		return err
+	}
+
+	for _, sub := range []string{dir, filepath.Join(dir, "projects")} {
+		if err := watcher.Add(sub); err != nil {
+			return err
+		}
+	}
+
+	go func() {
+		for {
+			select {
+			case event, ok := <-watcher.Events:
+				if !ok {
+					return
+				}
+				if event.Op&(fsnotify.Write|fsnotify.Create|fsnotify.Remove|fsnotify.Rename) == 0 {
+					continue
+				}
+
+				if err := c.load(dir); err != nil {
+					log.Error("Couldn't reload content", err)
+					continue
+				}
+
+				onReload()
+			case err, ok := <-watcher.Errors:
+				if !ok {
+					return
+				}
+				log.Error("Content watcher error", err)
+			}
+		}
+	}()
+
+	return nil
+}
@@ -1,7 +1,7 @@
 package main
 
 import (
-	"fmt"
+	"strconv"
 	"strings"
 )
 
@@ -24,16 +24,54 @@ func SplitFrontmatterMarkdown(content string) (string, map[string]interface{}) {
 				// +2 because first line after last --- is blank
 				return strings.Join(lines[i+2:], "\n"), fm
 			} else if strings.Contains(line, ":") {
-				// otherwise, parse valid line as k/v pair
-				parts := strings.Split(line, ":")
+				// otherwise, parse valid line as k/v pair. join parts[1:] back together
+				// with ":" so values like timestamps don't get truncated at the first colon
+				parts := strings.SplitN(line, ":", 2)
 				key := strings.TrimSpace(parts[0])
-				value := strings.TrimSpace(parts[1])
-				fm[key] = value
+				fm[key] = parseFrontmatterValue(strings.TrimSpace(parts[1]))
 			}
 		}
 	}
 
 	// if no frontmatter found, return the content as is
-	fmt.Println("no frontmatter found")
 	return content, fm
 }
+
+// parseFrontmatterValue converts a raw frontmatter value into a bool, int,
+// []interface{} (for "[a, b, c]" lists), or string, in that order of
+// preference. Quoted strings have their quotes stripped; everything else
+// that doesn't match a more specific type is returned as-is.
+func parseFrontmatterValue(raw string) interface{} {
+	switch raw {
+	case "true":
+		return true
+	case "false":
+		return false
+	}
+
+	if n, err := strconv.Atoi(raw); err == nil {
+		return n
+	}
+
+	if strings.HasPrefix(raw, "[") && strings.HasSuffix(raw, "]") {
+		inner := strings.TrimSpace(raw[1 : len(raw)-1])
+		if inner == "" {
+			return []interface{}{}
+		}
+
+		items := strings.Split(inner, ",")
+		list := make([]interface{}, len(items))
+		for i, item := range items {
+			list[i] = parseFrontmatterValue(strings.TrimSpace(item))
+		}
+		return list
+	}
+
+	if len(raw) >= 2 {
+		if (raw[0] == '"' && raw[len(raw)-1] == '"') || (raw[0] == '\'' && raw[len(raw)-1] == '\'') {
+			return raw[1 : len(raw)-1]
+		}
+	}
+
+	return raw
+}
@@ -0,0 +1,244 @@
+package main
+
+import (
+	"encoding/json"
+	"errors"
+	"fmt"
+	"os"
+	"strings"
+	"sync"
+	"time"
+)
+
+const (
+	guestbookLogPath       = "guestbook.jsonl"
+	guestbookRecentEntries = 20
+	guestbookPostCooldown  = time.Hour
+)
+
+// errGuestbookRateLimited is returned by (*guestbook).post when fingerprint
+// has already posted within guestbookPostCooldown.
+var errGuestbookRateLimited = errors.New("you can only post once per hour")
+
+// GuestbookEntry is a single record in the guestbook log. A tombstone (a
+// delete) is represented by a later record with the same ID and Deleted set,
+// everything else left zero.
+type GuestbookEntry struct {
+	ID          string    `json:"id"`
+	Timestamp   time.Time `json:"timestamp"`
+	Fingerprint string    `json:"fingerprint"`
+	Nick        string    `json:"nick,omitempty"`
+	Body        string    `json:"body,omitempty"`
+	Deleted     bool      `json:"deleted,omitempty"`
+}
+
+// guestbook is the append-only, JSONL-backed guestbook log. Entries are kept
+// in memory (guarded by mu) so reads don't need to touch disk; writes append
+// a record to path and then update the in-memory copy.
+type guestbook struct {
+	mu   sync.Mutex
+	path string
+
+	entries []GuestbookEntry     // in log order, including deleted tombstoned entries
+	posted  map[string]time.Time // fingerprint -> timestamp of their last post, for rate limiting
+}
+
+// newGuestbook loads path (if it exists) into a ready-to-use guestbook.
+func newGuestbook(path string) (*guestbook, error) {
+	gb := &guestbook{path: path, posted: make(map[string]time.Time)}
+
+	if err := gb.load(); err != nil && !os.IsNotExist(err) {
+		// gb is still safe to use (just empty); report the error but let the
+		// caller decide whether to treat it as fatal
+		return gb, err
+	}
+
+	return gb, nil
+}
+
+// load reads every record in path and replays it into the in-memory log.
+func (gb *guestbook) load() error {
+	raw, err := os.ReadFile(gb.path)
+	if err != nil {
+		return err
+	}
+
+	index := make(map[string]int)
+	var entries []GuestbookEntry
+
+	for _, line := range strings.Split(string(raw), "\n") {
+		if line == "" {
+			continue
+		}
+
+		var rec GuestbookEntry
+		if err := json.Unmarshal([]byte(line), &rec); err != nil {
+			continue
+		}
+
+		if rec.Deleted {
+			if i, ok := index[rec.ID]; ok {
+				entries[i].Deleted = true
+			}
+			continue
+		}
+
+		index[rec.ID] = len(entries)
+		entries = append(entries, rec)
+		gb.posted[rec.Fingerprint] = rec.Timestamp
+	}
+
+	gb.entries = entries
+
+	return nil
+}
+
+// post appends a new entry from fingerprint, rate-limited to one post per
+// fingerprint per guestbookPostCooldown.
+func (gb *guestbook) post(fingerprint, nick, body string, now time.Time) error {
+	gb.mu.Lock()
+	defer gb.mu.Unlock()
+
+	if last, ok := gb.posted[fingerprint]; ok && now.Sub(last) < guestbookPostCooldown {
+		return errGuestbookRateLimited
+	}
+
+	entry := GuestbookEntry{
+		ID:          fmt.Sprintf("%s-%d", fingerprint, now.UnixNano()),
+		Timestamp:   now,
+		Fingerprint: fingerprint,
+		Nick:        nick,
+		Body:        body,
+	}
+
+	if err := gb.appendRecord(entry); err != nil {
+		return err
+	}
+
+	gb.entries = append(gb.entries, entry)
+	gb.posted[fingerprint] = now
+
+	return nil
+}
+
+// delete tombstones the entry with the given ID by appending a delete record
+// to the log, rather than rewriting or removing anything already written.
+func (gb *guestbook) delete(id string) error {
+	gb.mu.Lock()
+	defer gb.mu.Unlock()
+
+	if err := gb.appendRecord(GuestbookEntry{ID: id, Deleted: true}); err != nil {
+		return err
+	}
+
+	for i := range gb.entries {
+		if gb.entries[i].ID == id {
+			gb.entries[i].Deleted = true
+			break
+		}
+	}
+
+	return nil
+}
+
+// appendRecord writes entry as one JSON line to the log file.
+func (gb *guestbook) appendRecord(entry GuestbookEntry) error {
+	f, err := os.OpenFile(gb.path, os.O_APPEND|os.O_CREATE|os.O_WRONLY, 0644)
+	if err != nil {
+		return err
+	}
+	defer f.Close()
+
+	data, err := json.Marshal(entry)
+	if err != nil {
+		return err
+	}
+
+	_, err = f.Write(append(data, '\n'))
+	return err
+}
+
+// recent returns the n most recent non-deleted entries, newest first.
+func (gb *guestbook) recent(n int) []GuestbookEntry {
+	gb.mu.Lock()
+	defer gb.mu.Unlock()
+
+	var visible []GuestbookEntry
+	for _, e := range gb.entries {
+		if !e.Deleted {
+			visible = append(visible, e)
+		}
+	}
+
+	if len(visible) > n {
+		visible = visible[len(visible)-n:]
+	}
+
+	for i, j := 0, len(visible)-1; i < j; i, j = i+1, j-1 {
+		visible[i], visible[j] = visible[j], visible[i]
+	}
+
+	return visible
+}
+
+// shortFingerprint trims an "SHA256:<base64>" fingerprint down to something
+// that fits on one line next to a nick.
+func shortFingerprint(fp string) string {
+	const keep = 12
+	if len(fp) <= keep {
+		return fp
+	}
+	return fp[:keep] + "…"
+}
+
+// GuestbookComposerView renders the nick/body composer shown while
+// m.guestbookComposing is true.
+func GuestbookComposerView(m model) string {
+	var status string
+	if m.guestbookStatus != "" {
+		status = m.styles.Subtle(m.guestbookStatus) + "\n\n"
+	}
+
+	return status + m.guestbookNick.View() + "\n" + m.guestbookBody.View()
+}
+
+// GuestbookBodyView renders the composer (if open) followed by the most
+// recent entries, each rendered through glamour with its fingerprint shown
+// via m.styles.Subtle. The selected entry (admins only) is marked with a
+// cursor.
+func GuestbookBodyView(m model) string {
+	var sections []string
+
+	if m.guestbookComposing {
+		sections = append(sections, GuestbookComposerView(m))
+	} else {
+		prompt := fmt.Sprintf("%s %s", m.styles.Normal("n"), m.styles.Subtle("sign the guestbook"))
+		if m.guestbookStatus != "" {
+			prompt += "  " + m.styles.Muted("•") + " " + m.styles.Subtle(m.guestbookStatus)
+		}
+		sections = append(sections, prompt)
+	}
+
+	if len(m.guestbookEntries) == 0 {
+		sections = append(sections, m.styles.Subtle("no entries yet — be the first to sign!"))
+	}
+
+	for i, entry := range m.guestbookEntries {
+		cursor := "  "
+		if m.guestbookIsAdmin && i == m.guestbookCursor {
+			cursor = m.styles.Highlight("› ")
+		}
+
+		meta := m.styles.Normal(entry.Nick) + "  " + m.styles.Subtle(shortFingerprint(entry.Fingerprint)) +
+			"  " + m.styles.Muted(entry.Timestamp.Format("2006-01-02 15:04"))
+
+		var body string
+		if i < len(m.guestbookRenders) {
+			body = m.guestbookRenders[i]
+		}
+
+		sections = append(sections, cursor+meta+"\n"+body)
+	}
+
+	return strings.Join(sections, "\n\n")
+}
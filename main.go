@@ -8,9 +8,12 @@ import (
 	"os"
 	"os/signal"
 	"strings"
+	"sync"
 	"syscall"
 	"time"
 
+	"github.com/charmbracelet/bubbles/textarea"
+	"github.com/charmbracelet/bubbles/textinput"
 	"github.com/charmbracelet/bubbles/viewport"
 	tea "github.com/charmbracelet/bubbletea"
 	"github.com/charmbracelet/glamour"
@@ -22,9 +25,20 @@ import (
 	"github.com/charmbracelet/wish/activeterm"
 	"github.com/charmbracelet/wish/bubbletea"
 	"github.com/charmbracelet/wish/logging"
+	"github.com/muesli/termenv"
+	gossh "golang.org/x/crypto/ssh"
 	"golang.org/x/term"
 )
 
+type page int
+
+const (
+	pageHome page = iota
+	pagePosts
+	pagePost
+	pageGuestbook
+)
+
 type model struct {
 	loaded   bool           // whether or not the viewport is loaded
 	viewport viewport.Model // mostly holds glamour output
@@ -32,69 +46,96 @@ type model struct {
 	// terminal dims:
 	cmdWidth  int
 	cmdHeight int
-}
 
-const (
-	host                       = ""
-	port                       = "22"
-	maxWidth                   = 80
-	useHighPerformanceRenderer = true
+	page       page   // current route
+	posts      []Post // loaded from posts/ at server start
+	postCursor int    // selected row in the current (possibly filtered) posts list
+	activePost *Post  // post currently open, set when page == pagePost
 
-	headerHeight = 4
-	footerHeight = 4
-
-	top = `# hey, i'm max
-
-I study computer science and philosophy at Suffolk University, and design and develop web tools in my spare time. I can't comment on their usefulness, but they're hopefully a little cool.
+	filtering   bool            // true while the "/" fuzzy filter is open on the posts page
+	filterInput textinput.Model // fuzzy filter query, lives on model so it survives resizes
 
-## Recent Projects`
+	renderer *lipgloss.Renderer // bound to this session's color profile/dark-bg detection
+	styles   Styles             // color styles built from renderer
 
-	prog1 = `### Hypersearch
+	glamourRenderer *glamour.TermRenderer // this session's markdown renderer, rebuilt on resize
+	projectRenders  []string              // rendered content/projects/*.md, in order
+	flex            string                // laid-out project grid, built from projectRenders
 
-Hypersearch is a Chromium extension that provides power-user search tools. Slim down and streamline Google search result pages by filtering out spam results and blocking unnecessary info cards.`
+	guestbookFingerprint string // this session's SSH pubkey fingerprint
+	guestbookIsAdmin     bool   // true if guestbookFingerprint == adminFingerprint
 
-	prog2 = `### asciish
+	guestbookComposing bool            // true while the nick/body composer is focused
+	guestbookNick      textinput.Model // composer: nick field
+	guestbookBody      textarea.Model  // composer: body field
+	guestbookStatus    string          // transient status/error shown above the entries
 
-Asciish is a Vite/Rollup extension that provides build-time Unicode injection using shortcodes. This helps to keep source code UTF-8 compliant while allowing for the use of complex Unicode characters on a webpage.`
-
-	prog3 = `### escape-time
+	guestbookCursor  int              // selected entry, admins only (for "d" to delete)
+	guestbookEntries []GuestbookEntry // the most recent entries, newest first
+	guestbookRenders []string         // glamour-rendered body for each of guestbookEntries
+}
 
-Escape time is a small WebGL fractal explorer thrown together over a weekend for a Suffolk University Math Society presentation. It supports a few different fractals and was mostly a WebGL learning experience.`
+const (
+	host     = ""
+	port     = "22"
+	maxWidth = 80
 
-	prog4 = `### Clippy
+	headerHeight = 4
+	footerHeight = 4
 
-Clippy.mov is a web-based video editor built using FFmpeg.wasm. I stopped developing it after the new school semester started, and recently came back to it. It's in active development.`
+	contentDir = "content"
+)
 
-	bot = `## Want to get in touch?
+// posts is loaded once at server start; sessions only ever read from it.
+var posts []Post
 
-Thanks, that's awesome! :D
-`
-)
+// guestbookLog is the shared, mutex-guarded guestbook store, opened once at
+// server start.
+var guestbookLog *guestbook
 
-var (
-	glamourRenderer  *glamour.TermRenderer // current renderer
-	ApplyNormal      = lipgloss.NewStyle().Foreground(lipgloss.AdaptiveColor{Light: "#27272A", Dark: "#A1A1AA"}).Render
-	ApplySubtle      = lipgloss.NewStyle().Foreground(lipgloss.AdaptiveColor{Light: "#52525B", Dark: "#71717A"}).Render
-	ApplyMuted       = lipgloss.NewStyle().Foreground(lipgloss.AdaptiveColor{Light: "#D4D4D8", Dark: "#3F3F46"}).Render
-	ApplyHighlight   = lipgloss.NewStyle().Foreground(lipgloss.AdaptiveColor{Light: "#B824BB", Dark: "#F93EFD"}).Render
-	tableBorderColor = lipgloss.AdaptiveColor{Light: "#D4D4D8", Dark: "#3F3F46"}
-
-	projRender1, projRender2, projRender3, projRender4 string
-	flex                                               string
-)
+// adminFingerprint is the SSH pubkey fingerprint allowed to delete guestbook
+// entries. Unset (the default) means no session is an admin.
+var adminFingerprint = os.Getenv("GUESTBOOK_ADMIN_FINGERPRINT")
 
 // loads server
 func main() {
 	// set glamour env
 	os.Setenv("GLAMOUR_STYLE", "./style.json")
 
+	// load posts once at startup; sessions only ever read from this slice
+	var err error
+	posts, err = loadPosts("posts")
+	if err != nil {
+		log.Error("Couldn't load posts", err)
+	}
+
+	// load content/ once at startup, then watch it for changes so edits show up
+	// without a server restart
+	if err := content.load(contentDir); err != nil {
+		log.Error("Couldn't load content", err)
+	}
+
+	if err := watchContent(contentDir, content, broadcastContentReload); err != nil {
+		log.Error("Couldn't watch content", err)
+	}
+
+	// open the guestbook log once at startup; sessions share this through its mutex
+	guestbookLog, err = newGuestbook(guestbookLogPath)
+	if err != nil {
+		log.Error("Couldn't open guestbook", err)
+	}
+
 	// set up a new wish server. This allows us to serve a terminal UI over SSH
 	srv, err := wish.NewServer(
 		wish.WithAddress(net.JoinHostPort(host, port)),
 		// ED25519 key generated by default
 		wish.WithHostKeyPath(".ssh/id_ed25519"),
+		// accept any offered public key so every session gets a stable
+		// fingerprint identity for the guestbook, without requiring an
+		// out-of-band signup step
+		wish.WithPublicKeyAuth(func(ssh.Context, ssh.PublicKey) bool { return true }),
 		wish.WithMiddleware(
-			bubbletea.Middleware(teaHandler),
+			bubbletea.MiddlewareWithProgramHandler(teaProgramHandler, termenv.Ascii),
 			activeterm.Middleware(),
 			logging.Middleware(),
 		),
@@ -145,18 +186,95 @@ func teaHandler(s ssh.Session) (tea.Model, []tea.ProgramOption) {
 	computedWidth := min(physWidth, maxWidth)
 
 	vp := viewport.New(physWidth, physHeight-headerHeight-footerHeight)
-	vp.HighPerformanceRendering = useHighPerformanceRenderer
+
+	filterInput := textinput.New()
+	filterInput.Prompt = "/ "
+	filterInput.Placeholder = "filter posts…"
+
+	guestbookNick := textinput.New()
+	guestbookNick.Prompt = "nick: "
+	guestbookNick.Placeholder = "anonymous"
+	guestbookNick.CharLimit = 32
+
+	guestbookBody := textarea.New()
+	guestbookBody.Placeholder = "leave a note…"
+	guestbookBody.CharLimit = 500
+	guestbookBody.SetHeight(3)
+	guestbookBody.ShowLineNumbers = false
+
+	// bound to this session's PTY, so AdaptiveColor resolves against its own
+	// reported color profile/dark-bg instead of a process-wide default
+	renderer := bubbletea.MakeRenderer(s)
+
+	var fingerprint string
+	if pk := s.PublicKey(); pk != nil {
+		fingerprint = gossh.FingerprintSHA256(pk)
+	}
 
 	m := model{
-		viewport: vp,
-		fitWidth: computedWidth,
-		loaded:   false,
+		viewport:             vp,
+		fitWidth:             computedWidth,
+		loaded:               false,
+		page:                 pageHome,
+		posts:                posts,
+		filterInput:          filterInput,
+		renderer:             renderer,
+		styles:               NewStyles(renderer),
+		guestbookFingerprint: fingerprint,
+		guestbookIsAdmin:     fingerprint != "" && fingerprint == adminFingerprint,
+		guestbookNick:        guestbookNick,
+		guestbookBody:        guestbookBody,
 	}
 
 	// FIXME: dont use tea.WithMouseCellMotion() because it seems to break the viewport when scrolling fast
 	return m, []tea.ProgramOption{tea.WithMouseCellMotion(), tea.WithAltScreen()}
 }
 
+var (
+	activeProgramsMu sync.Mutex
+	activePrograms   = make(map[*tea.Program]struct{})
+)
+
+// contentReloadedMsg is broadcast to every active session when the fsnotify
+// watcher picks up a change under content/.
+type contentReloadedMsg struct{}
+
+// broadcastContentReload notifies every active session that content/ changed
+// so it can re-render with the fresh copy.
+func broadcastContentReload() {
+	activeProgramsMu.Lock()
+	defer activeProgramsMu.Unlock()
+
+	for p := range activePrograms {
+		p.Send(contentReloadedMsg{})
+	}
+}
+
+// teaProgramHandler wraps teaHandler so we can hang on to the resulting
+// *tea.Program, letting broadcastContentReload push messages into every
+// live session.
+func teaProgramHandler(s ssh.Session) *tea.Program {
+	m, opts := teaHandler(s)
+	if m == nil {
+		return nil
+	}
+
+	p := tea.NewProgram(m, append(opts, bubbletea.MakeOptions(s)...)...)
+
+	activeProgramsMu.Lock()
+	activePrograms[p] = struct{}{}
+	activeProgramsMu.Unlock()
+
+	go func() {
+		<-s.Context().Done()
+		activeProgramsMu.Lock()
+		delete(activePrograms, p)
+		activeProgramsMu.Unlock()
+	}()
+
+	return p
+}
+
 // this function runs in two cases:
 //  1. we've navigated to a new file (needsNewFile = true)
 //  2. the best fit width has changed (needsNewFile = false)
@@ -164,18 +282,27 @@ func teaHandler(s ssh.Session) (tea.Model, []tea.ProgramOption) {
 // and handles updating viewport/glamour/list logic
 func RerenderContent(m model, needsNewFile bool, needsNewTerm bool) (model, tea.Cmd) {
 	// we've navigated to the posts "page", which *does not* use the viewport for rendering
-	// in this case, we set the viewport to 0x0. This is a hacky way to "clear" the viewport in high performance mode
+	// (it's keyboard-driven but not scrollable) — clear it so it doesn't show stale content
+	if m.page == pagePosts {
+		m.viewport.Width = 0
+		m.viewport.Height = 0
+		return m, nil
+	}
 
 	// we've navigated to a new page (not /posts), so we need to update the viewport and glamour renderer.
 
-	// first, sanity check the viewport height (may be 0x0 if we're in high performance mode and have just
-	// navigated away from /posts)
+	// first, sanity check the viewport dims (may be 0x0 if we've just navigated away from /posts)
+	m.viewport.Width = m.cmdWidth
 	m.viewport.Height = m.cmdHeight - headerHeight - footerHeight
 
+	layout, homeBody, contactBody, projects := content.snapshot()
+
 	if needsNewTerm {
-		// set up a new renderer
+		// set up a new renderer, pinned to this session's own color profile so two
+		// sessions with different terminals/widths never stomp on each other
 		renderer, err := glamour.NewTermRenderer(
 			glamour.WithEnvironmentConfig(),
+			glamour.WithColorProfile(m.renderer.ColorProfile()),
 			glamour.WithWordWrap(m.fitWidth),
 		)
 
@@ -184,11 +311,15 @@ func RerenderContent(m model, needsNewFile bool, needsNewTerm bool) (model, tea.
 			return m, tea.Quit
 		}
 
-		glamourRenderer = renderer
+		m.glamourRenderer = renderer
 
-		if m.fitWidth > 76 {
-			renderer, err = glamour.NewTermRenderer(
+		// the home page lays projects out in one or two columns based on the
+		// `layout:` key in content/home.md, rather than the available width
+		projRenderer := renderer
+		if layout != "single" && m.fitWidth > 76 {
+			projRenderer, err = glamour.NewTermRenderer(
 				glamour.WithEnvironmentConfig(),
+				glamour.WithColorProfile(m.renderer.ColorProfile()),
 				glamour.WithWordWrap(m.fitWidth/2-4),
 			)
 
@@ -198,61 +329,71 @@ func RerenderContent(m model, needsNewFile bool, needsNewTerm bool) (model, tea.
 			}
 		}
 
-		projRender1, _ = renderer.Render(prog1)
-		projRender2, _ = renderer.Render(prog2)
-		projRender3, _ = renderer.Render(prog3)
-		projRender4, _ = renderer.Render(prog4)
-
-		projRender1 = strings.TrimSuffix(strings.TrimPrefix(projRender1, "\n"), "\n")
-		projRender2 = strings.TrimSuffix(strings.TrimPrefix(projRender2, "\n"), "\n")
-		projRender3 = strings.TrimSuffix(strings.TrimPrefix(projRender3, "\n"), "\n")
-		projRender4 = strings.TrimSuffix(strings.TrimPrefix(projRender4, "\n"), "\n")
-
-		if m.fitWidth < 80 {
-			// remove last newlines
-			projRender4 = strings.TrimSuffix(projRender4, "\n")
+		renders := make([]string, len(projects))
+		for i, proj := range projects {
+			render, _ := projRenderer.Render(proj.Body)
+			renders[i] = strings.TrimSuffix(strings.TrimPrefix(render, "\n"), "\n")
+		}
+		m.projectRenders = renders
 
-			flex = lipgloss.JoinVertical(lipgloss.Left, projRender1, projRender2, projRender3, projRender4)
+		if layout == "single" {
+			m.flex = lipgloss.JoinVertical(lipgloss.Left, m.projectRenders...)
 		} else {
-			// remove last newlines
-			projRender3 = strings.TrimSuffix(projRender2, "\n")
-			projRender4 = strings.TrimSuffix(projRender4, "\n")
-
-			rowAHeight := max(strings.Count(projRender1, "\n"), strings.Count(projRender2, "\n"))
-			gapA := lipgloss.NewStyle().Width(4).Height(rowAHeight)
+			var rows []string
+			for i := 0; i < len(m.projectRenders); i += 2 {
+				if i+1 >= len(m.projectRenders) {
+					rows = append(rows, m.projectRenders[i])
+					break
+				}
+
+				rowHeight := max(strings.Count(m.projectRenders[i], "\n"), strings.Count(m.projectRenders[i+1], "\n"))
+				gap := m.renderer.NewStyle().Width(4).Height(rowHeight)
+				rows = append(rows, lipgloss.JoinHorizontal(lipgloss.Left, m.projectRenders[i], gap.Render(), m.projectRenders[i+1]))
+			}
+			m.flex = lipgloss.JoinVertical(lipgloss.Left, rows...)
+		}
+	}
 
-			rowBHeight := max(strings.Count(projRender3, "\n"), strings.Count(projRender4, "\n"))
-			gapB := lipgloss.NewStyle().Width(4).Height(rowBHeight)
+	if m.page == pageGuestbook && needsNewFile {
+		m.guestbookEntries = guestbookLog.recent(guestbookRecentEntries)
 
-			RowA := lipgloss.JoinHorizontal(lipgloss.Left, projRender1, gapA.Render(), projRender2)
-			RowB := lipgloss.JoinHorizontal(lipgloss.Left, projRender3, gapB.Render(), projRender4)
+		renders := make([]string, len(m.guestbookEntries))
+		for i, entry := range m.guestbookEntries {
+			render, _ := m.glamourRenderer.Render(entry.Body)
+			renders[i] = strings.TrimSuffix(strings.TrimPrefix(render, "\n"), "\n")
+		}
+		m.guestbookRenders = renders
 
-			flex = lipgloss.JoinVertical(lipgloss.Left, RowA, RowB)
+		if m.guestbookCursor >= len(m.guestbookEntries) {
+			m.guestbookCursor = max(0, len(m.guestbookEntries)-1)
 		}
 	}
 
-	topRender, _ := glamourRenderer.Render(top)
-	botRender, _ := glamourRenderer.Render(bot)
+	var cmb string
 
-	topRender = strings.TrimPrefix(topRender, "\n")
-	botRender = strings.TrimSuffix(botRender, "\n")
+	if m.page == pagePost && m.activePost != nil {
+		postRender, _ := m.glamourRenderer.Render(m.activePost.Body)
+		cmb = strings.TrimSuffix(strings.TrimPrefix(postRender, "\n"), "\n")
+	} else if m.page == pageGuestbook {
+		cmb = GuestbookBodyView(m)
+	} else {
+		topRender, _ := m.glamourRenderer.Render(homeBody)
+		botRender, _ := m.glamourRenderer.Render(contactBody)
 
-	contactA := lipgloss.Place(8, 1, lipgloss.Left, lipgloss.Center, ApplySubtle("email")) + ApplyMuted("•") + " " + ApplyNormal("max@mmyron.com") + "\n"
-	contactB := lipgloss.Place(8, 1, lipgloss.Left, lipgloss.Center, ApplySubtle("twitter")) + ApplyMuted("•") + " " + ApplyNormal("@mmorenthal") + "\n"
-	contactC := lipgloss.Place(8, 1, lipgloss.Left, lipgloss.Center, ApplySubtle("github")) + ApplyMuted("•") + " " + ApplyNormal("maxmmyron") + "\n"
+		topRender = strings.TrimPrefix(topRender, "\n")
+		botRender = strings.TrimSuffix(botRender, "\n")
 
-	cmb := lipgloss.JoinVertical(lipgloss.Top, topRender, flex, botRender, contactA, contactB, contactC)
+		contactA := lipgloss.Place(8, 1, lipgloss.Left, lipgloss.Center, m.styles.Subtle("email")) + m.styles.Muted("•") + " " + m.styles.Normal("max@mmyron.com") + "\n"
+		contactB := lipgloss.Place(8, 1, lipgloss.Left, lipgloss.Center, m.styles.Subtle("twitter")) + m.styles.Muted("•") + " " + m.styles.Normal("@mmorenthal") + "\n"
+		contactC := lipgloss.Place(8, 1, lipgloss.Left, lipgloss.Center, m.styles.Subtle("github")) + m.styles.Muted("•") + " " + m.styles.Normal("maxmmyron") + "\n"
 
-	// in high perf mode, View() doesn't seem to render content in *quite* the same way. here, we do some prelim.
-	// rendering by placing the rendered post in a container, and setting the viewport's content to that container
-	// (otherwise, we have no way of centering the content)
-	if useHighPerformanceRenderer {
-		container := lipgloss.Place(m.cmdWidth, m.viewport.Height, lipgloss.Center, lipgloss.Top, cmb)
-		m.viewport.SetContent(container)
-		return m, viewport.Sync(m.viewport)
+		cmb = lipgloss.JoinVertical(lipgloss.Top, topRender, m.flex, botRender, contactA, contactB, contactC)
 	}
 
-	m.viewport.SetContent(cmb)
+	// center the rendered content within the viewport's width so it reads the
+	// same regardless of how wide the terminal actually is
+	container := lipgloss.Place(m.cmdWidth, m.viewport.Height, lipgloss.Center, lipgloss.Top, cmb)
+	m.viewport.SetContent(container)
 	return m, nil
 }
 
@@ -260,8 +401,6 @@ func (m model) Init() tea.Cmd {
 	return nil
 }
 
-var lastFitWidth = 0
-
 func (m model) Update(msg tea.Msg) (tea.Model, tea.Cmd) {
 	var (
 		cmd  tea.Cmd
@@ -269,14 +408,20 @@ func (m model) Update(msg tea.Msg) (tea.Model, tea.Cmd) {
 	)
 
 	switch msg := msg.(type) {
+	case contentReloadedMsg:
+		// content/ changed on disk; force a full rerender with the fresh copy
+		m, cmd = RerenderContent(m, true, true)
+		return m, cmd
+
 	case tea.WindowSizeMsg:
-		lastFitWidth = m.fitWidth
+		lastFitWidth := m.fitWidth
 		m.fitWidth = min(msg.Width, maxWidth)
 		if m.fitWidth < 80 {
 			m.fitWidth = m.fitWidth - 4
 		}
 
-		// update the viewport height
+		// update the viewport dims
+		m.viewport.Width = msg.Width
 		m.viewport.Height = msg.Height - headerHeight - footerHeight
 
 		m.cmdWidth = msg.Width
@@ -284,7 +429,6 @@ func (m model) Update(msg tea.Msg) (tea.Model, tea.Cmd) {
 
 		// if we haven't loaded the viewport yet, then load root post
 		if !m.loaded {
-			m.viewport.HighPerformanceRendering = useHighPerformanceRenderer
 			m.loaded = true
 			m, cmd = RerenderContent(m, true, true)
 			cmds = append(cmds, cmd)
@@ -299,21 +443,183 @@ func (m model) Update(msg tea.Msg) (tea.Model, tea.Cmd) {
 			cmds = append(cmds, cmd)
 		}
 
-		// resync on resize if using high performance renderer
-		if useHighPerformanceRenderer {
-			// we have to manually offset the viewport so the header renders correctly
-			m.viewport.YPosition = headerHeight + 1
-			cmds = append(cmds, viewport.Sync(m.viewport))
-		}
-
 		return m, tea.Batch(cmds...)
 
 	case tea.KeyMsg:
-		switch msg.String() {
-		case "q", "ctrl+c":
+		if msg.String() == "ctrl+c" || (msg.String() == "q" && !m.guestbookComposing && !m.filtering) {
 			return m, tea.Quit
-		default:
-			m.viewport, cmd = m.viewport.Update(msg)
+		}
+
+		switch m.page {
+		case pageHome:
+			switch msg.String() {
+			case "p":
+				m.page = pagePosts
+				m.postCursor = 0
+				m, cmd = RerenderContent(m, true, false)
+				cmds = append(cmds, cmd)
+			case "g":
+				m.page = pageGuestbook
+				m.guestbookCursor = 0
+				m, cmd = RerenderContent(m, true, false)
+				cmds = append(cmds, cmd)
+			default:
+				m.viewport, cmd = m.viewport.Update(msg)
+			}
+
+		case pagePosts:
+			if m.filtering {
+				switch msg.String() {
+				case "esc":
+					m.filtering = false
+					m.filterInput.Reset()
+					m.filterInput.Blur()
+					m.postCursor = 0
+				case "up":
+					if m.postCursor > 0 {
+						m.postCursor--
+					}
+				case "down":
+					visible, _ := visiblePosts(m)
+					if m.postCursor < len(visible)-1 {
+						m.postCursor++
+					}
+				case "enter":
+					if visible, _ := visiblePosts(m); len(visible) > 0 {
+						selected := visible[m.postCursor]
+						m.activePost = &selected
+						m.page = pagePost
+						m.filtering = false
+						m.filterInput.Reset()
+						m.filterInput.Blur()
+						m, cmd = RerenderContent(m, true, true)
+						cmds = append(cmds, cmd)
+					}
+				default:
+					m.filterInput, cmd = m.filterInput.Update(msg)
+					m.postCursor = 0
+					cmds = append(cmds, cmd)
+				}
+				break
+			}
+
+			switch msg.String() {
+			case "esc", "h":
+				m.page = pageHome
+				m.filterInput.Reset()
+				m, cmd = RerenderContent(m, true, true)
+				cmds = append(cmds, cmd)
+			case "/":
+				m.filtering = true
+				cmds = append(cmds, m.filterInput.Focus())
+			case "j", "down":
+				if m.postCursor < len(m.posts)-1 {
+					m.postCursor++
+				}
+			case "k", "up":
+				if m.postCursor > 0 {
+					m.postCursor--
+				}
+			case "enter":
+				if len(m.posts) > 0 {
+					selected := m.posts[m.postCursor]
+					m.activePost = &selected
+					m.page = pagePost
+					m, cmd = RerenderContent(m, true, true)
+					cmds = append(cmds, cmd)
+				}
+			}
+
+		case pagePost:
+			switch msg.String() {
+			case "esc", "h":
+				m.activePost = nil
+				m.page = pagePosts
+				m, cmd = RerenderContent(m, true, false)
+				cmds = append(cmds, cmd)
+			default:
+				m.viewport, cmd = m.viewport.Update(msg)
+			}
+
+		case pageGuestbook:
+			if m.guestbookComposing {
+				switch msg.String() {
+				case "esc":
+					m.guestbookComposing = false
+					m.guestbookNick.Blur()
+					m.guestbookBody.Blur()
+				case "tab":
+					if m.guestbookNick.Focused() {
+						m.guestbookNick.Blur()
+						cmds = append(cmds, m.guestbookBody.Focus())
+					} else {
+						m.guestbookBody.Blur()
+						cmds = append(cmds, m.guestbookNick.Focus())
+					}
+				case "ctrl+s":
+					nick := strings.TrimSpace(m.guestbookNick.Value())
+					body := strings.TrimSpace(m.guestbookBody.Value())
+
+					if body == "" {
+						m.guestbookStatus = "write something first"
+						break
+					}
+					if nick == "" {
+						nick = "anonymous"
+					}
+
+					if err := guestbookLog.post(m.guestbookFingerprint, nick, body, time.Now()); err != nil {
+						m.guestbookStatus = err.Error()
+						break
+					}
+
+					m.guestbookComposing = false
+					m.guestbookNick.Reset()
+					m.guestbookNick.Blur()
+					m.guestbookBody.Reset()
+					m.guestbookBody.Blur()
+					m.guestbookStatus = "posted!"
+					m, cmd = RerenderContent(m, true, false)
+					cmds = append(cmds, cmd)
+				default:
+					if m.guestbookNick.Focused() {
+						m.guestbookNick, cmd = m.guestbookNick.Update(msg)
+					} else {
+						m.guestbookBody, cmd = m.guestbookBody.Update(msg)
+					}
+					cmds = append(cmds, cmd)
+				}
+				break
+			}
+
+			switch msg.String() {
+			case "esc", "h":
+				m.page = pageHome
+				m, cmd = RerenderContent(m, true, true)
+				cmds = append(cmds, cmd)
+			case "n":
+				m.guestbookComposing = true
+				m.guestbookStatus = ""
+				cmds = append(cmds, m.guestbookNick.Focus())
+			case "j", "k":
+				if msg.String() == "j" && m.guestbookCursor < len(m.guestbookEntries)-1 {
+					m.guestbookCursor++
+				} else if msg.String() == "k" && m.guestbookCursor > 0 {
+					m.guestbookCursor--
+				}
+			case "d":
+				if m.guestbookIsAdmin && m.guestbookCursor < len(m.guestbookEntries) {
+					entry := m.guestbookEntries[m.guestbookCursor]
+					if err := guestbookLog.delete(entry.ID); err != nil {
+						m.guestbookStatus = err.Error()
+						break
+					}
+					m, cmd = RerenderContent(m, true, false)
+					cmds = append(cmds, cmd)
+				}
+			default:
+				m.viewport, cmd = m.viewport.Update(msg)
+			}
 		}
 	}
 
@@ -333,13 +639,42 @@ func (m model) View() string {
 	header := HeaderView(m)
 	footer := FooterView(m)
 
-	// default inner content to a bunch of newlines, so we know where the footer goes (remember, the viewport bypasses
-	// this View() fn because we're in high perf. render mode)
-	var inner = strings.Repeat("\n", max(0, m.viewport.Height-1))
+	// the posts list is keyboard-driven but not scrollable, so it skips the viewport
+	// entirely and renders straight into the page body
+	var inner string
+	if m.page == pagePosts {
+		body := PostsListView(m)
+		if m.filtering {
+			body = m.filterInput.View() + "\n\n" + body
+		}
 
-	combinedVp := lipgloss.JoinVertical(lipgloss.Top, header, inner, footer)
+		listHeight := m.cmdHeight - headerHeight - footerHeight
+		inner = lipgloss.Place(m.cmdWidth, listHeight, lipgloss.Center, lipgloss.Top, body)
+	} else {
+		inner = m.viewport.View()
+	}
 
-	return lipgloss.NewStyle().Width(m.cmdWidth).Height(m.cmdHeight).Align(lipgloss.Center, lipgloss.Top).Render(combinedVp)
+	return lipgloss.JoinVertical(lipgloss.Top, header, inner, footer)
+}
+
+// scrollCapBorder gives the scroll-percentage indicator its "┤ 37% ├" look:
+// a border with only the left/right edges drawn.
+var scrollCapBorder = lipgloss.Border{Left: "┤", Right: "├"}
+
+// truncateToWidth returns the longest prefix of s (by whole rune) whose
+// display width fits within width.
+func truncateToWidth(s string, width int) string {
+	var b strings.Builder
+	w := 0
+	for _, r := range s {
+		rw := lipgloss.Width(string(r))
+		if w+rw > width {
+			break
+		}
+		b.WriteRune(r)
+		w += rw
+	}
+	return b.String()
 }
 
 func HeaderView(m model) string {
@@ -348,35 +683,89 @@ func HeaderView(m model) string {
 		linkPadding  = 2
 	)
 
-	content := ApplyNormal("mmyron.com/")
+	path := "mmyron.com/"
+	switch m.page {
+	case pagePosts:
+		path = "mmyron.com/posts"
+	case pagePost:
+		if m.activePost != nil {
+			path = "mmyron.com/posts/" + m.activePost.Slug
+		}
+	case pageGuestbook:
+		path = "mmyron.com/guestbook"
+	}
+
+	var scrollPct string
+	if m.page == pageHome || m.page == pagePost || m.page == pageGuestbook {
+		scrollPct = m.renderer.NewStyle().
+			Border(scrollCapBorder, false, true, false, true).
+			BorderForeground(m.styles.TableBorderColor).
+			Padding(0, 1).
+			Render(fmt.Sprintf("%3.f%%", m.viewport.ScrollPercent()*100))
+	}
 
 	// calculate widths for main content
 	mainWidth := m.fitWidth - altLinkWidth
-	mainContentWidth := mainWidth - 2 - 2*linkPadding // 2 for the border, 4 for the padding
+	mainContentWidth := mainWidth - 2 - 2*linkPadding - lipgloss.Width(scrollPct) // 2 for the border, 4 for the padding
+	if mainContentWidth < 0 {
+		mainContentWidth = 0
+	}
 
-	// truncate if necessary
-	if len(content) > mainContentWidth {
-		content = content[:mainContentWidth-4] + "..."
+	// truncate the raw path to its display width before styling it, so we
+	// never cut a multi-byte rune or an ANSI escape sequence in half
+	if lipgloss.Width(path) > mainContentWidth {
+		truncWidth := mainContentWidth - 4
+		if truncWidth < 0 {
+			truncWidth = 0
+		}
+		path = truncateToWidth(path, truncWidth) + "..."
 	}
 
+	content := m.styles.Normal(path)
+
 	var (
-		pathStyle = lipgloss.NewStyle().Width(mainContentWidth).Padding(0, linkPadding).Render
+		pathStyle = m.renderer.NewStyle().Width(mainContentWidth).Padding(0, linkPadding).Render
 	)
 
-	t := table.New().BorderColumn(true).Width(m.fitWidth).Border(lipgloss.NormalBorder()).BorderStyle(lipgloss.NewStyle().Foreground(tableBorderColor))
-	t.Row(pathStyle(content))
+	t := table.New().BorderColumn(true).Width(m.fitWidth).Border(lipgloss.NormalBorder()).BorderStyle(m.renderer.NewStyle().Foreground(m.styles.TableBorderColor))
+	t.Row(pathStyle(content) + scrollPct)
 
-	return lipgloss.NewStyle().Width(m.cmdWidth).Height(headerHeight).Align(lipgloss.Center, lipgloss.Top).SetString(t.Render()).Render()
+	return m.renderer.NewStyle().Width(m.cmdWidth).Height(headerHeight).Align(lipgloss.Center, lipgloss.Top).SetString(t.Render()).Render()
 }
 
 func FooterView(m model) string {
-	// ▲/▼ scroll  •  q quit
-	scrollHelp := fmt.Sprintf("%s %s", ApplyNormal("↑ /↓"), ApplySubtle("scroll"))
-	quitHelp := fmt.Sprintf("%s %s", ApplyNormal("q"), ApplySubtle("quit"))
-	help := fmt.Sprintf("%s  %s  %s", scrollHelp, ApplyMuted("•"), quitHelp)
+	quitHelp := fmt.Sprintf("%s %s", m.styles.Normal("q"), m.styles.Subtle("quit"))
+
+	pageHelp := fmt.Sprintf("%s %s", m.styles.Normal("pgup/pgdn"), m.styles.Subtle("page"))
+
+	var navHelp string
+	switch m.page {
+	case pageHome:
+		navHelp = fmt.Sprintf("%s %s  %s %s  %s", m.styles.Normal("p"), m.styles.Subtle("posts"), m.styles.Normal("g"), m.styles.Subtle("guestbook"), pageHelp)
+	case pagePosts:
+		if m.filtering {
+			navHelp = fmt.Sprintf("%s %s  %s %s  %s %s", m.styles.Normal("↑ /↓"), m.styles.Subtle("move"), m.styles.Normal("enter"), m.styles.Subtle("open"), m.styles.Normal("esc"), m.styles.Subtle("cancel"))
+		} else {
+			navHelp = fmt.Sprintf("%s %s  %s %s  %s %s  %s %s", m.styles.Normal("j/k"), m.styles.Subtle("move"), m.styles.Normal("/"), m.styles.Subtle("filter"), m.styles.Normal("enter"), m.styles.Subtle("open"), m.styles.Normal("esc/h"), m.styles.Subtle("back"))
+		}
+	case pagePost:
+		navHelp = fmt.Sprintf("%s %s  %s  %s %s", m.styles.Normal("↑ /↓"), m.styles.Subtle("scroll"), pageHelp, m.styles.Normal("esc/h"), m.styles.Subtle("back"))
+	case pageGuestbook:
+		if m.guestbookComposing {
+			navHelp = fmt.Sprintf("%s %s  %s %s  %s %s", m.styles.Normal("tab"), m.styles.Subtle("switch field"), m.styles.Normal("ctrl+s"), m.styles.Subtle("post"), m.styles.Normal("esc"), m.styles.Subtle("cancel"))
+		} else {
+			navHelp = fmt.Sprintf("%s %s  %s", m.styles.Normal("n"), m.styles.Subtle("sign"), pageHelp)
+			if m.guestbookIsAdmin {
+				navHelp += fmt.Sprintf("  %s %s", m.styles.Normal("j/k d"), m.styles.Subtle("delete"))
+			}
+			navHelp += fmt.Sprintf("  %s %s", m.styles.Normal("esc/h"), m.styles.Subtle("back"))
+		}
+	}
+
+	help := fmt.Sprintf("%s  %s  %s", navHelp, m.styles.Muted("•"), quitHelp)
 
 	helpSection := lipgloss.Place(m.fitWidth, footerHeight-1, lipgloss.Center, lipgloss.Center, help)
-	borderContainer := lipgloss.NewStyle().Width(m.fitWidth).Height(footerHeight-1).Align(lipgloss.Center, lipgloss.Bottom).Border(lipgloss.NormalBorder(), true, false, false).BorderForeground(tableBorderColor).SetString(helpSection).Render()
+	borderContainer := m.renderer.NewStyle().Width(m.fitWidth).Height(footerHeight-1).Align(lipgloss.Center, lipgloss.Bottom).Border(lipgloss.NormalBorder(), true, false, false).BorderForeground(m.styles.TableBorderColor).SetString(helpSection).Render()
 
 	return lipgloss.Place(m.cmdWidth, footerHeight, lipgloss.Center, lipgloss.Bottom, borderContainer)
 }
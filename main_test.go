@@ -0,0 +1,55 @@
+package main
+
+import (
+	"bytes"
+	"testing"
+
+	tea "github.com/charmbracelet/bubbletea"
+	"github.com/charmbracelet/lipgloss"
+	"github.com/muesli/termenv"
+)
+
+// TestRerenderContentIsolatedPerSession guards against the package-level
+// globals this replaced: two concurrent sessions built with different
+// widths and color profiles must produce different output, and resizing one
+// must never leak into the other's state.
+func TestRerenderContentIsolatedPerSession(t *testing.T) {
+	if err := content.load(contentDir); err != nil {
+		t.Fatalf("content.load: %v", err)
+	}
+
+	newSession := func(width, height int, profile termenv.Profile) model {
+		renderer := lipgloss.NewRenderer(&bytes.Buffer{}, termenv.WithProfile(profile))
+
+		m := model{
+			fitWidth:  width,
+			cmdWidth:  width,
+			cmdHeight: height,
+			page:      pageHome,
+			renderer:  renderer,
+			styles:    NewStyles(renderer),
+		}
+
+		rendered, cmd := RerenderContent(m, true, true)
+		if cmd != nil {
+			if _, ok := cmd().(tea.QuitMsg); ok {
+				t.Fatalf("RerenderContent quit unexpectedly")
+			}
+		}
+		return rendered
+	}
+
+	narrow := newSession(60, 30, termenv.Ascii)
+	wide := newSession(80, 40, termenv.TrueColor)
+
+	narrowView := narrow.View()
+	wideView := wide.View()
+
+	if narrowView == wideView {
+		t.Fatal("expected sessions with different widths/color profiles to render differently")
+	}
+
+	if narrow.fitWidth != 60 || wide.fitWidth != 80 {
+		t.Fatal("expected each session to retain its own fitWidth")
+	}
+}
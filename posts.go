@@ -0,0 +1,202 @@
+package main
+
+import (
+	"os"
+	"path/filepath"
+	"sort"
+	"strings"
+
+	"github.com/sahilm/fuzzy"
+)
+
+// Post is a single posts/*.md entry, split into its frontmatter and body.
+type Post struct {
+	Slug  string
+	Title string
+	Date  string
+	Tags  []string
+	Body  string
+}
+
+// loadPosts reads every *.md file in dir, parses its frontmatter via
+// SplitFrontmatterMarkdown, and returns the resulting posts sorted by date
+// descending (newest first).
+func loadPosts(dir string) ([]Post, error) {
+	entries, err := os.ReadDir(dir)
+	if err != nil {
+		return nil, err
+	}
+
+	var posts []Post
+
+	for _, entry := range entries {
+		if entry.IsDir() || filepath.Ext(entry.Name()) != ".md" {
+			continue
+		}
+
+		raw, err := os.ReadFile(filepath.Join(dir, entry.Name()))
+		if err != nil {
+			return nil, err
+		}
+
+		body, fm := SplitFrontmatterMarkdown(string(raw))
+
+		post := Post{
+			Slug: strings.TrimSuffix(entry.Name(), ".md"),
+			Body: strings.TrimSuffix(body, "\n"),
+		}
+
+		if title, ok := fm["title"].(string); ok {
+			post.Title = title
+		}
+		if date, ok := fm["date"].(string); ok {
+			post.Date = date
+		}
+		switch tags := fm["tags"].(type) {
+		case []interface{}:
+			for _, tag := range tags {
+				if s, ok := tag.(string); ok && s != "" {
+					post.Tags = append(post.Tags, s)
+				}
+			}
+		case string:
+			for _, tag := range strings.Split(tags, ",") {
+				if tag = strings.TrimSpace(tag); tag != "" {
+					post.Tags = append(post.Tags, tag)
+				}
+			}
+		}
+
+		posts = append(posts, post)
+	}
+
+	sort.Slice(posts, func(i, j int) bool {
+		return posts[i].Date > posts[j].Date
+	})
+
+	return posts, nil
+}
+
+// postSource adapts []Post to fuzzy.Source, matching against each post's
+// title, tags, and body combined.
+type postSource []Post
+
+func (ps postSource) String(i int) string {
+	return ps[i].Title + " " + strings.Join(ps[i].Tags, " ") + " " + ps[i].Body
+}
+
+func (ps postSource) Len() int { return len(ps) }
+
+// filterPosts fuzzy-matches query against posts and returns the matching
+// posts ranked by score, along with the matched rune indexes (relative to
+// each post's title) for highlighting. An empty query returns posts as-is,
+// preserving the date-descending ordering from loadPosts.
+func filterPosts(posts []Post, query string) ([]Post, [][]int) {
+	if query == "" {
+		return posts, nil
+	}
+
+	matches := fuzzy.FindFrom(query, postSource(posts))
+
+	filtered := make([]Post, len(matches))
+	highlights := make([][]int, len(matches))
+
+	for i, match := range matches {
+		filtered[i] = posts[match.Index]
+
+		title := posts[match.Index].Title
+		var titleIdx []int
+		for _, idx := range match.MatchedIndexes {
+			if idx >= len(title) {
+				break
+			}
+			titleIdx = append(titleIdx, runeIndexAtByteOffset(title, idx))
+		}
+		highlights[i] = titleIdx
+	}
+
+	return filtered, highlights
+}
+
+// runeIndexAtByteOffset converts a byte offset into s to the index of the
+// rune starting at (or containing) that offset, matching how
+// fuzzy.Match.MatchedIndexes addresses the original byte string.
+func runeIndexAtByteOffset(s string, byteOffset int) int {
+	runeIdx := 0
+	for byteIdx := range s {
+		if byteIdx > byteOffset {
+			break
+		}
+		if byteIdx == byteOffset {
+			return runeIdx
+		}
+		runeIdx++
+	}
+	return runeIdx
+}
+
+// visiblePosts returns the posts currently shown on the posts page, applying
+// the live fuzzy filter query when present.
+func visiblePosts(m model) ([]Post, [][]int) {
+	return filterPosts(m.posts, m.filterInput.Value())
+}
+
+// highlightTitle wraps the runes of title at the given indexes in
+// styles.Highlight, leaving the rest untouched.
+func highlightTitle(styles Styles, title string, indexes []int) string {
+	if len(indexes) == 0 {
+		return styles.Normal(title)
+	}
+
+	matched := make(map[int]bool, len(indexes))
+	for _, idx := range indexes {
+		matched[idx] = true
+	}
+
+	var b strings.Builder
+	for i, r := range []rune(title) {
+		if matched[i] {
+			b.WriteString(styles.Highlight(string(r)))
+		} else {
+			b.WriteString(styles.Normal(string(r)))
+		}
+	}
+
+	return b.String()
+}
+
+// PostsListView renders the keyboard-navigable posts list: one row per post,
+// with the currently selected row highlighted and fuzzy matches underlined
+// via styles.Highlight.
+func PostsListView(m model) string {
+	posts, highlights := visiblePosts(m)
+
+	if len(posts) == 0 {
+		return m.styles.Subtle("no posts found")
+	}
+
+	var rows []string
+
+	for i, post := range posts {
+		var hl []int
+		if i < len(highlights) {
+			hl = highlights[i]
+		}
+
+		cursor := "  "
+		title := highlightTitle(m.styles, post.Title, hl)
+		if i == m.postCursor {
+			cursor = m.styles.Highlight("› ")
+			title = m.styles.Highlight(post.Title)
+		}
+
+		meta := m.styles.Subtle(post.Date)
+		if len(post.Tags) > 0 {
+			meta += m.styles.Muted(" • ") + m.styles.Subtle(strings.Join(post.Tags, ", "))
+		}
+
+		rows = append(rows, cursor+title+"  "+meta)
+	}
+
+	return strings.Join(rows, "\n")
+}
@@ -0,0 +1,27 @@
+package main
+
+import "github.com/charmbracelet/lipgloss"
+
+// Styles holds the color styles used throughout the TUI, bound to a single
+// session's lipgloss.Renderer so AdaptiveColor resolves against that
+// session's own light/dark terminal background rather than a process-wide
+// default.
+type Styles struct {
+	Normal    func(...string) string
+	Subtle    func(...string) string
+	Muted     func(...string) string
+	Highlight func(...string) string
+
+	TableBorderColor lipgloss.AdaptiveColor
+}
+
+// NewStyles builds a Styles bound to r.
+func NewStyles(r *lipgloss.Renderer) Styles {
+	return Styles{
+		Normal:           r.NewStyle().Foreground(lipgloss.AdaptiveColor{Light: "#27272A", Dark: "#A1A1AA"}).Render,
+		Subtle:           r.NewStyle().Foreground(lipgloss.AdaptiveColor{Light: "#52525B", Dark: "#71717A"}).Render,
+		Muted:            r.NewStyle().Foreground(lipgloss.AdaptiveColor{Light: "#D4D4D8", Dark: "#3F3F46"}).Render,
+		Highlight:        r.NewStyle().Foreground(lipgloss.AdaptiveColor{Light: "#B824BB", Dark: "#F93EFD"}).Render,
+		TableBorderColor: lipgloss.AdaptiveColor{Light: "#D4D4D8", Dark: "#3F3F46"},
+	}
+}